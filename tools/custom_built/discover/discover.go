@@ -0,0 +1,235 @@
+// Package discover implements host-discovery sweeps that narrow a CIDR
+// range down to the hosts that are actually alive before a port scan runs.
+package discover
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// tcpPingPorts are tried, in order, when raw ICMP sockets aren't available
+// (e.g. running unprivileged).
+var tcpPingPorts = []int{80, 443}
+
+// PingSweep expands cidr and probes every host concurrently, returning the
+// subset that responded. It prefers an ICMP echo sweep and falls back to a
+// TCP ping against tcpPingPorts when raw ICMP sockets can't be opened
+// (typically because the process isn't running as root).
+func PingSweep(cidr string, timeout time.Duration, workers int) ([]net.IP, error) {
+	hosts, err := expandCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("discover: %w", err)
+	}
+
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		return tcpPingSweep(hosts, timeout, workers), nil
+	}
+	defer conn.Close()
+
+	return icmpPingSweep(conn, hosts, timeout, workers), nil
+}
+
+// expandCIDR lists every usable host address (excluding network and
+// broadcast, where applicable) within cidr.
+func expandCIDR(cidr string) ([]net.IP, error) {
+	ip, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+	}
+
+	var hosts []net.IP
+	for cur := ip.Mask(ipNet.Mask); ipNet.Contains(cur); incIP(cur) {
+		next := make(net.IP, len(cur))
+		copy(next, cur)
+		hosts = append(hosts, next)
+	}
+	if len(hosts) > 2 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
+
+// incIP increments an IP address in place.
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return
+		}
+	}
+}
+
+// icmpPingSweep sends an ICMP echo request to every host over conn and
+// collects the addresses that reply within timeout. A single reader
+// goroutine demuxes replies by peer address (mirroring the reply trackers
+// in scanner/syn.go and scanner/udp.go) so sending fans out across workers
+// without racing on the one shared socket's reads.
+func icmpPingSweep(conn *icmp.PacketConn, hosts []net.IP, timeout time.Duration, workers int) []net.IP {
+	pconn := conn.IPv4PacketConn()
+	tracker := newEchoReplyTracker()
+	go tracker.listen(pconn)
+	defer tracker.stop()
+
+	jobs := make(chan net.IP, len(hosts))
+	for _, h := range hosts {
+		jobs <- h
+	}
+	close(jobs)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				sendEcho(pconn, host)
+			}
+		}()
+	}
+	wg.Wait()
+	time.Sleep(timeout)
+
+	var results []net.IP
+	for _, host := range hosts {
+		if tracker.alive(host) {
+			results = append(results, host)
+		}
+	}
+	return results
+}
+
+// sendEcho transmits a single ICMP echo request to host.
+func sendEcho(pconn *ipv4.PacketConn, host net.IP) {
+	msg := icmp.Message{
+		Type: ipv4.ICMPTypeEcho,
+		Code: 0,
+		Body: &icmp.Echo{
+			ID:   1,
+			Seq:  1,
+			Data: []byte("cybersecurity-toolkit ping sweep"),
+		},
+	}
+	wb, err := msg.Marshal(nil)
+	if err != nil {
+		return
+	}
+	pconn.WriteTo(wb, nil, &net.IPAddr{IP: host})
+}
+
+// echoReplyTracker records which hosts have answered an ICMP echo request,
+// read by a single goroutine off the shared socket so concurrent senders
+// never race over who gets to see a given reply.
+type echoReplyTracker struct {
+	mu   sync.Mutex
+	seen map[string]bool
+	done chan struct{}
+}
+
+func newEchoReplyTracker() *echoReplyTracker {
+	return &echoReplyTracker{seen: make(map[string]bool), done: make(chan struct{})}
+}
+
+// listen reads ICMP packets off pconn and marks the sender alive on any
+// echo reply, until stop is called.
+func (t *echoReplyTracker) listen(pconn *ipv4.PacketConn) {
+	buf := make([]byte, 1500)
+	for {
+		select {
+		case <-t.done:
+			return
+		default:
+		}
+
+		pconn.SetReadDeadline(time.Now().Add(250 * time.Millisecond))
+		n, _, peer, err := pconn.ReadFrom(buf)
+		if err != nil {
+			continue
+		}
+
+		peerIP, ok := peer.(*net.IPAddr)
+		if !ok {
+			continue
+		}
+
+		reply, err := icmp.ParseMessage(1, buf[:n])
+		if err != nil || reply.Type != ipv4.ICMPTypeEchoReply {
+			continue
+		}
+
+		t.mu.Lock()
+		t.seen[peerIP.IP.String()] = true
+		t.mu.Unlock()
+	}
+}
+
+// alive reports whether host has been seen to reply.
+func (t *echoReplyTracker) alive(host net.IP) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.seen[host.String()]
+}
+
+// stop shuts down the background listener.
+func (t *echoReplyTracker) stop() {
+	close(t.done)
+}
+
+// tcpPingSweep probes every host on tcpPingPorts, treating any successful
+// connect (or even a fast refusal, which still proves the host is up) as
+// alive. Used when raw ICMP sockets aren't available.
+func tcpPingSweep(hosts []net.IP, timeout time.Duration, workers int) []net.IP {
+	jobs := make(chan net.IP, len(hosts))
+	for _, h := range hosts {
+		jobs <- h
+	}
+	close(jobs)
+
+	alive := make(chan net.IP, len(hosts))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for host := range jobs {
+				if tcpPing(host, timeout) {
+					alive <- host
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(alive)
+	}()
+
+	var results []net.IP
+	for ip := range alive {
+		results = append(results, ip)
+	}
+	return results
+}
+
+// tcpPing reports whether host accepted or actively refused a TCP
+// connection on any of tcpPingPorts — both prove the host is alive,
+// unlike a timeout.
+func tcpPing(host net.IP, timeout time.Duration) bool {
+	for _, port := range tcpPingPorts {
+		address := net.JoinHostPort(host.String(), fmt.Sprintf("%d", port))
+		conn, err := net.DialTimeout("tcp", address, timeout)
+		if err == nil {
+			conn.Close()
+			return true
+		}
+		if opErr, ok := err.(*net.OpError); ok && !opErr.Timeout() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+// Package fingerprint performs lightweight service identification on an
+// already-open TCP connection.
+package fingerprint
+
+import (
+	"bufio"
+	"crypto/tls"
+	"net"
+	"strings"
+	"time"
+)
+
+// readTimeout bounds how long Fingerprint will wait for a probe response.
+const readTimeout = 2 * time.Second
+
+// wellKnownServices maps common ports to the service name probed for them.
+var wellKnownServices = map[int]string{
+	22:   "ssh",
+	25:   "smtp",
+	80:   "http",
+	110:  "pop3",
+	143:  "imap",
+	443:  "https",
+	3306: "mysql",
+	6379: "redis",
+}
+
+// Fingerprint attempts to identify the service listening on conn by sending
+// a protocol-appropriate probe (or none, for services that banner on
+// connect) and reading back the first line of the response. It returns an
+// empty service/banner pair if the port isn't well-known or nothing is
+// read before the deadline.
+func Fingerprint(conn net.Conn, port int) (service, banner string) {
+	service = wellKnownServices[port]
+
+	conn.SetDeadline(time.Now().Add(readTimeout))
+	defer conn.SetDeadline(time.Time{})
+
+	switch port {
+	case 443:
+		return service, fingerprintTLS(conn)
+	case 80, 8000, 8080:
+		conn.Write([]byte("HEAD / HTTP/1.0\r\n\r\n"))
+	case 6379:
+		conn.Write([]byte("PING\r\n"))
+	}
+	// SSH, SMTP, POP3, IMAP, MySQL and unrecognized ports all banner
+	// immediately on connect, so no probe is written for them.
+
+	return service, readLine(conn)
+}
+
+// readLine reads a single newline- or CR-terminated line from conn,
+// trimming surrounding whitespace. It returns "" on timeout or EOF.
+func readLine(conn net.Conn) string {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil && line == "" {
+		return ""
+	}
+	return strings.TrimSpace(line)
+}
+
+// fingerprintTLS negotiates a TLS handshake over conn and summarizes the
+// server's chosen version and certificate subject as a banner string.
+func fingerprintTLS(conn net.Conn) string {
+	client := tls.Client(conn, &tls.Config{InsecureSkipVerify: true})
+	if err := client.Handshake(); err != nil {
+		return ""
+	}
+
+	state := client.ConnectionState()
+	banner := tlsVersionName(state.Version)
+	if len(state.PeerCertificates) > 0 {
+		banner += " " + state.PeerCertificates[0].Subject.CommonName
+	}
+	return strings.TrimSpace(banner)
+}
+
+// tlsVersionName renders a tls.Config version constant as a short label.
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS13:
+		return "TLSv1.3"
+	case tls.VersionTLS12:
+		return "TLSv1.2"
+	case tls.VersionTLS11:
+		return "TLSv1.1"
+	case tls.VersionTLS10:
+		return "TLSv1.0"
+	default:
+		return "TLS"
+	}
+}
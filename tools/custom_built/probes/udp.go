@@ -0,0 +1,72 @@
+// Package probes holds the protocol-specific payloads UDP scanning sends to
+// coax a reply out of common services that would otherwise stay silent.
+package probes
+
+import (
+	"encoding/binary"
+	"math/rand"
+)
+
+// Table maps a well-known UDP port to the probe builder used to elicit a
+// reply from it. New probes are added here.
+var Table = map[int]func() []byte{
+	53:  dnsQuery,
+	137: netbiosQuery,
+	123: ntpRequest,
+	161: snmpGetRequest,
+}
+
+// Probe returns the payload to send to port, or an empty datagram if the
+// port has no dedicated probe.
+func Probe(port int) []byte {
+	if build, ok := Table[port]; ok {
+		return build()
+	}
+	return []byte{}
+}
+
+// dnsQuery builds a minimal DNS query for the root NS record, enough to
+// make any resolver on port 53 reply.
+func dnsQuery() []byte {
+	buf := make([]byte, 12, 17)
+	binary.BigEndian.PutUint16(buf[0:2], uint16(rand.Intn(1<<16)))
+	buf[2] = 0x01                           // RD (recursion desired)
+	binary.BigEndian.PutUint16(buf[4:6], 1) // QDCOUNT = 1
+
+	buf = append(buf, 0x00)       // root label
+	buf = append(buf, 0x00, 0x02) // QTYPE  = NS
+	buf = append(buf, 0x00, 0x01) // QCLASS = IN
+	return buf
+}
+
+// netbiosQuery is a NetBIOS Name Service NBSTAT query for the wildcard name
+// "*", the standard way to fingerprint a Windows/Samba host over UDP/137.
+var netbiosQueryBytes = []byte{
+	0x82, 0x28, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0x20, 0x43, 0x4b, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41,
+	0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41,
+	0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41, 0x41,
+	0x00, 0x00, 0x21, 0x00, 0x01,
+}
+
+func netbiosQuery() []byte { return netbiosQueryBytes }
+
+// ntpRequest is a bare NTPv3 client request: LI=0, VN=3, Mode=3, the rest
+// zeroed. Any NTP server will reply with a timestamped response.
+func ntpRequest() []byte {
+	buf := make([]byte, 48)
+	buf[0] = 0x1b
+	return buf
+}
+
+// snmpGetRequest is an SNMPv1 GetRequest for sysDescr.0 (1.3.6.1.2.1.1.1.0)
+// using the "public" community string, the de-facto default used to probe
+// for SNMP agents.
+var snmpGetRequestBytes = []byte{
+	0x30, 0x29, 0x02, 0x01, 0x00, 0x04, 0x06, 'p', 'u', 'b', 'l', 'i', 'c',
+	0xa0, 0x1c, 0x02, 0x04, 0x6f, 0x46, 0x74, 0x5f, 0x02, 0x01, 0x00, 0x02, 0x01, 0x00,
+	0x30, 0x0e, 0x30, 0x0c, 0x06, 0x08, 0x2b, 0x06, 0x01, 0x02, 0x01, 0x01, 0x01, 0x00,
+	0x05, 0x00,
+}
+
+func snmpGetRequest() []byte { return snmpGetRequestBytes }
@@ -0,0 +1,53 @@
+package probes
+
+import "testing"
+
+func TestProbeKnownPorts(t *testing.T) {
+	cases := []struct {
+		port      int
+		wantFirst byte
+		wantLen   int
+	}{
+		{port: 53, wantLen: 17},
+		{port: 137, wantFirst: 0x82, wantLen: len(netbiosQueryBytes)},
+		{port: 123, wantFirst: 0x1b, wantLen: 48},
+		{port: 161, wantFirst: 0x30, wantLen: len(snmpGetRequestBytes)},
+	}
+
+	for _, tc := range cases {
+		payload := Probe(tc.port)
+		if len(payload) != tc.wantLen {
+			t.Errorf("port %d: len = %d, want %d", tc.port, len(payload), tc.wantLen)
+		}
+		if tc.port != 53 && payload[0] != tc.wantFirst {
+			t.Errorf("port %d: first byte = %#x, want %#x", tc.port, payload[0], tc.wantFirst)
+		}
+	}
+}
+
+func TestProbeUnknownPortReturnsEmpty(t *testing.T) {
+	if payload := Probe(9999); len(payload) != 0 {
+		t.Errorf("Probe(9999) = %v, want empty datagram", payload)
+	}
+}
+
+func TestTableCoversProbeEntries(t *testing.T) {
+	for port := range Table {
+		if len(Probe(port)) == 0 {
+			t.Errorf("Table[%d] produced an empty payload", port)
+		}
+	}
+}
+
+func TestDNSQueryIsWellFormed(t *testing.T) {
+	buf := dnsQuery()
+	if len(buf) != 17 {
+		t.Fatalf("len = %d, want 17", len(buf))
+	}
+	if buf[2] != 0x01 {
+		t.Errorf("RD flag = %#x, want 0x01", buf[2])
+	}
+	if buf[4] != 0x00 || buf[5] != 0x01 {
+		t.Errorf("QDCOUNT = %d, want 1", int(buf[4])<<8|int(buf[5]))
+	}
+}
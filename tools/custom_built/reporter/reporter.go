@@ -0,0 +1,243 @@
+// Package reporter renders scan findings in pluggable output formats so the
+// toolkit can be composed with downstream pipelines.
+package reporter
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+// Reporter receives one call per port finding and a final Finish call once
+// the scan completes.
+type Reporter interface {
+	// Report records a single finding. state is one of the Port* state
+	// constants and is rendered verbatim, so scanners that distinguish more
+	// than open/closed (e.g. UDP's open|filtered) aren't misreported as
+	// definitively open.
+	Report(host string, port int, state, service, banner string)
+	// Finish flushes any buffered output and writes a closing summary.
+	Finish() error
+}
+
+// Port states a Reporter may be asked to render. Scanners pass these
+// through verbatim; reporter never infers a state on its own.
+const (
+	PortOpen         = "open"
+	PortClosed       = "closed"
+	PortOpenFiltered = "open|filtered"
+)
+
+// finding is the common record shape shared by every Reporter
+// implementation.
+type finding struct {
+	Host    string `json:"host"`
+	Port    int    `json:"port"`
+	State   string `json:"state"`
+	Service string `json:"service,omitempty"`
+	Banner  string `json:"banner,omitempty"`
+}
+
+// TextReporter writes a sorted, per-host plain-text summary, matching the
+// toolkit's original stdout output.
+type TextReporter struct {
+	w        io.Writer
+	findings []finding
+}
+
+// NewTextReporter returns a Reporter that writes human-readable text to w.
+func NewTextReporter(w io.Writer) *TextReporter {
+	return &TextReporter{w: w}
+}
+
+func (r *TextReporter) Report(host string, port int, state, service, banner string) {
+	r.findings = append(r.findings, finding{Host: host, Port: port, State: state, Service: service, Banner: banner})
+}
+
+func (r *TextReporter) Finish() error {
+	sort.Slice(r.findings, func(i, j int) bool {
+		if r.findings[i].Host != r.findings[j].Host {
+			return r.findings[i].Host < r.findings[j].Host
+		}
+		return r.findings[i].Port < r.findings[j].Port
+	})
+
+	var currentHost string
+	for _, f := range r.findings {
+		if f.Host != currentHost {
+			fmt.Fprintf(r.w, "%s:\n", f.Host)
+			currentHost = f.Host
+		}
+		fmt.Fprintf(r.w, "  Port %d %s%s\n", f.Port, f.State, describeService(f.Service, f.Banner))
+	}
+	return nil
+}
+
+// describeService renders a fingerprinted service/banner pair as a
+// trailing annotation, e.g. " (http: Apache/2.4.41)", or "" if unknown.
+func describeService(service, banner string) string {
+	switch {
+	case service == "" && banner == "":
+		return ""
+	case banner == "":
+		return fmt.Sprintf(" (%s)", service)
+	case service == "":
+		return fmt.Sprintf(" (%s)", banner)
+	default:
+		return fmt.Sprintf(" (%s: %s)", service, banner)
+	}
+}
+
+// JSONReporter writes one JSON object per finding as it is reported,
+// followed by a final summary object on Finish.
+type JSONReporter struct {
+	w         io.Writer
+	enc       *json.Encoder
+	target    string
+	workers   int
+	start     time.Time
+	findCount int
+}
+
+// jsonSummary is the closing object written by JSONReporter.Finish.
+type jsonSummary struct {
+	Target   string        `json:"target"`
+	Workers  int           `json:"workers"`
+	Findings int           `json:"findings"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// NewJSONReporter returns a Reporter that writes newline-delimited JSON
+// findings to w, annotated with target and workers for the closing summary.
+func NewJSONReporter(w io.Writer, target string, workers int) *JSONReporter {
+	return &JSONReporter{w: w, enc: json.NewEncoder(w), target: target, workers: workers, start: time.Now()}
+}
+
+func (r *JSONReporter) Report(host string, port int, state, service, banner string) {
+	r.findCount++
+	r.enc.Encode(finding{Host: host, Port: port, State: state, Service: service, Banner: banner})
+}
+
+func (r *JSONReporter) Finish() error {
+	return r.enc.Encode(jsonSummary{
+		Target:   r.target,
+		Workers:  r.workers,
+		Findings: r.findCount,
+		Duration: time.Since(r.start),
+	})
+}
+
+// NmapXMLReporter writes an nmap-compatible <nmaprun> XML document that
+// downstream tools expecting nmap's native output can ingest directly.
+type NmapXMLReporter struct {
+	w        io.Writer
+	target   string
+	workers  int
+	protocol string
+	start    time.Time
+	hosts    map[string][]finding
+	order    []string
+}
+
+// NewNmapXMLReporter returns a Reporter that writes an nmap XML document to
+// w once Finish is called. protocol ("tcp" or "udp") is stamped onto every
+// <port> element, since a single scan run is always one protocol.
+func NewNmapXMLReporter(w io.Writer, target string, workers int, protocol string) *NmapXMLReporter {
+	return &NmapXMLReporter{w: w, target: target, workers: workers, protocol: protocol, start: time.Now(), hosts: make(map[string][]finding)}
+}
+
+func (r *NmapXMLReporter) Report(host string, port int, state, service, banner string) {
+	if _, ok := r.hosts[host]; !ok {
+		r.order = append(r.order, host)
+	}
+	r.hosts[host] = append(r.hosts[host], finding{Host: host, Port: port, State: state, Service: service, Banner: banner})
+}
+
+func (r *NmapXMLReporter) Finish() error {
+	doc := nmapRun{
+		Scanner:  "cybersecurity-toolkit",
+		Args:     r.target,
+		Start:    r.start.Unix(),
+		RunStats: nmapRunStats{Finished: nmapFinished{Time: time.Now().Unix()}},
+	}
+	for _, host := range r.order {
+		nh := nmapHost{
+			Status:  nmapStatus{State: "up"},
+			Address: nmapAddress{Addr: host, AddrType: "ipv4"},
+		}
+		for _, f := range r.hosts[host] {
+			nh.Ports.Port = append(nh.Ports.Port, nmapPort{
+				Protocol: r.protocol,
+				PortID:   f.Port,
+				State:    nmapState{State: f.State},
+				Service:  nmapService{Name: f.Service, Product: f.Banner},
+			})
+		}
+		doc.Hosts = append(doc.Hosts, nh)
+	}
+
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(r.w)
+	enc.Indent("", "  ")
+	return enc.Encode(doc)
+}
+
+// The nmap* types below mirror just enough of nmap's native XML schema
+// (https://nmap.org/book/nmap-dtd.html) for downstream tooling that
+// consumes nmap output to parse our results directly.
+type nmapRun struct {
+	XMLName  xml.Name     `xml:"nmaprun"`
+	Scanner  string       `xml:"scanner,attr"`
+	Args     string       `xml:"args,attr"`
+	Start    int64        `xml:"start,attr"`
+	Hosts    []nmapHost   `xml:"host"`
+	RunStats nmapRunStats `xml:"runstats"`
+}
+
+type nmapHost struct {
+	Status  nmapStatus  `xml:"status"`
+	Address nmapAddress `xml:"address"`
+	Ports   nmapPorts   `xml:"ports"`
+}
+
+type nmapStatus struct {
+	State string `xml:"state,attr"`
+}
+
+type nmapAddress struct {
+	Addr     string `xml:"addr,attr"`
+	AddrType string `xml:"addrtype,attr"`
+}
+
+type nmapPorts struct {
+	Port []nmapPort `xml:"port"`
+}
+
+type nmapPort struct {
+	Protocol string      `xml:"protocol,attr"`
+	PortID   int         `xml:"portid,attr"`
+	State    nmapState   `xml:"state"`
+	Service  nmapService `xml:"service"`
+}
+
+type nmapState struct {
+	State string `xml:"state,attr"`
+}
+
+type nmapService struct {
+	Name    string `xml:"name,attr,omitempty"`
+	Product string `xml:"product,attr,omitempty"`
+}
+
+type nmapRunStats struct {
+	Finished nmapFinished `xml:"finished"`
+}
+
+type nmapFinished struct {
+	Time int64 `xml:"time,attr"`
+}
@@ -0,0 +1,102 @@
+package reporter
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestTextReporterFinish(t *testing.T) {
+	cases := []struct {
+		name     string
+		findings []finding
+		want     string
+	}{
+		{
+			name:     "no service or banner",
+			findings: []finding{{Host: "10.0.0.1", Port: 22, State: PortOpen}},
+			want:     "10.0.0.1:\n  Port 22 open\n",
+		},
+		{
+			name:     "service and banner",
+			findings: []finding{{Host: "10.0.0.1", Port: 80, State: PortOpen, Service: "http", Banner: "Apache/2.4.41"}},
+			want:     "10.0.0.1:\n  Port 80 open (http: Apache/2.4.41)\n",
+		},
+		{
+			name: "sorts hosts and ports before grouping",
+			findings: []finding{
+				{Host: "10.0.0.2", Port: 22, State: PortOpen},
+				{Host: "10.0.0.1", Port: 443, State: PortOpen},
+				{Host: "10.0.0.1", Port: 80, State: PortOpen},
+			},
+			want: "10.0.0.1:\n  Port 80 open\n  Port 443 open\n10.0.0.2:\n  Port 22 open\n",
+		},
+		{
+			name:     "open|filtered is never rendered as a bare open",
+			findings: []finding{{Host: "10.0.0.1", Port: 53, State: PortOpenFiltered}},
+			want:     "10.0.0.1:\n  Port 53 open|filtered\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			r := NewTextReporter(&buf)
+			r.findings = append(r.findings, tc.findings...)
+			if err := r.Finish(); err != nil {
+				t.Fatalf("Finish: %v", err)
+			}
+			if got := buf.String(); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestJSONReporterFinishSummary(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewJSONReporter(&buf, "10.0.0.0/24", 50)
+	r.Report("10.0.0.1", 22, PortOpen, "ssh", "")
+	r.Report("10.0.0.1", 80, PortOpen, "http", "")
+
+	if err := r.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3 (2 findings + summary): %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[2], `"findings":2`) {
+		t.Errorf("summary line missing findings count: %s", lines[2])
+	}
+	if !strings.Contains(lines[2], `"workers":50`) {
+		t.Errorf("summary line missing workers: %s", lines[2])
+	}
+}
+
+func TestNmapXMLReporterSetsProtocolAndState(t *testing.T) {
+	var buf bytes.Buffer
+	r := NewNmapXMLReporter(&buf, "10.0.0.0/24", 50, "udp")
+	r.Report("10.0.0.1", 53, PortOpenFiltered, "dns", "")
+
+	if err := r.Finish(); err != nil {
+		t.Fatalf("Finish: %v", err)
+	}
+
+	var doc nmapRun
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if len(doc.Hosts) != 1 || len(doc.Hosts[0].Ports.Port) != 1 {
+		t.Fatalf("unexpected document shape: %+v", doc)
+	}
+	port := doc.Hosts[0].Ports.Port[0]
+	if port.Protocol != "udp" {
+		t.Errorf("protocol = %q, want %q", port.Protocol, "udp")
+	}
+	if port.State.State != PortOpenFiltered {
+		t.Errorf("state = %q, want %q (an open|filtered UDP port must not be serialized as definitively open)", port.State.State, PortOpenFiltered)
+	}
+}
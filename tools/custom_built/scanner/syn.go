@@ -0,0 +1,227 @@
+// Package scanner implements raw, low-level scan techniques that go beyond
+// a plain net.Dial connect scan.
+package scanner
+
+import (
+	"fmt"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/google/gopacket"
+	"github.com/google/gopacket/layers"
+	"github.com/google/gopacket/pcap"
+	"github.com/google/gopacket/routing"
+)
+
+// Port states returned by SYNScan.
+const (
+	StateOpen     = "open"
+	StateClosed   = "closed"
+	StateFiltered = "filtered"
+)
+
+// SYNScan performs a half-open (stealth) TCP scan against target: a SYN is
+// sent for each port and the port is classified by the reply (SYN-ACK is
+// open, RST is closed, no reply within timeout is filtered) without ever
+// completing the TCP handshake. It requires CAP_NET_RAW (or root) to open
+// a raw packet capture handle.
+func SYNScan(target net.IP, ports []int, timeout time.Duration) (map[int]string, error) {
+	router, err := routing.New()
+	if err != nil {
+		return nil, fmt.Errorf("scanner: build routing table: %w", err)
+	}
+
+	iface, gateway, srcIP, err := router.Route(target)
+	if err != nil {
+		return nil, fmt.Errorf("scanner: resolve route to %s: %w", target, err)
+	}
+
+	handle, err := pcap.OpenLive(iface.Name, 65535, true, pcap.BlockForever)
+	if err != nil {
+		return nil, fmt.Errorf("scanner: open pcap handle on %s (requires CAP_NET_RAW or root): %w", iface.Name, err)
+	}
+	defer handle.Close()
+
+	dst := gateway
+	if dst == nil {
+		dst = target
+	}
+	dstHW, err := arpResolve(handle, iface, srcIP, dst)
+	if err != nil {
+		return nil, fmt.Errorf("scanner: resolve destination MAC: %w", err)
+	}
+
+	if err := handle.SetBPFFilter(fmt.Sprintf("tcp and src host %s", target)); err != nil {
+		return nil, fmt.Errorf("scanner: set bpf filter: %w", err)
+	}
+
+	srcPort := layers.TCPPort(1024 + rand.Intn(64512))
+	packetSource := gopacket.NewPacketSource(handle, handle.LinkType())
+	tracker := newSYNReplyTracker()
+	go tracker.listen(packetSource, srcPort)
+
+	// Fire every SYN up front; a single listener goroutine demuxes replies
+	// by port as they arrive, so this scales the way the connect-scan
+	// worker pool does instead of waiting a full timeout per port.
+	for _, port := range ports {
+		sendSYN(handle, iface, dstHW, srcIP, target, srcPort, layers.TCPPort(port))
+	}
+	time.Sleep(timeout)
+
+	results := make(map[int]string, len(ports))
+	for _, port := range ports {
+		if state, ok := tracker.state(port); ok {
+			results[port] = state
+		} else {
+			results[port] = StateFiltered
+		}
+	}
+	return results, nil
+}
+
+// sendSYN crafts and transmits a single raw TCP SYN packet.
+func sendSYN(handle *pcap.Handle, iface *net.Interface, dstHW net.HardwareAddr, srcIP, dstIP net.IP, srcPort, dstPort layers.TCPPort) error {
+	eth := layers.Ethernet{
+		SrcMAC:       iface.HardwareAddr,
+		DstMAC:       dstHW,
+		EthernetType: layers.EthernetTypeIPv4,
+	}
+	ip := layers.IPv4{
+		Version:  4,
+		TTL:      64,
+		SrcIP:    srcIP,
+		DstIP:    dstIP,
+		Protocol: layers.IPProtocolTCP,
+	}
+	tcp := layers.TCP{
+		SrcPort: srcPort,
+		DstPort: dstPort,
+		SYN:     true,
+		Window:  14600,
+		Seq:     rand.Uint32(),
+	}
+	if err := tcp.SetNetworkLayerForChecksum(&ip); err != nil {
+		return err
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+	if err := gopacket.SerializeLayers(buf, opts, &eth, &ip, &tcp); err != nil {
+		return fmt.Errorf("serialize SYN packet: %w", err)
+	}
+	return handle.WritePacketData(buf.Bytes())
+}
+
+// synReplyTracker records, per port, the state implied by the first
+// definitive reply (SYN-ACK or RST) observed for it, so replies for every
+// in-flight port can be demuxed off a single capture stream concurrently
+// instead of one port being waited on at a time.
+type synReplyTracker struct {
+	mu     sync.Mutex
+	states map[int]string
+}
+
+func newSYNReplyTracker() *synReplyTracker {
+	return &synReplyTracker{states: make(map[int]string)}
+}
+
+// listen continuously decodes captured packets addressed to srcPort and
+// records the state each one implies for its source port.
+func (t *synReplyTracker) listen(source *gopacket.PacketSource, srcPort layers.TCPPort) {
+	for packet := range source.Packets() {
+		tcpLayer := packet.Layer(layers.LayerTypeTCP)
+		if tcpLayer == nil {
+			continue
+		}
+		tcp, ok := tcpLayer.(*layers.TCP)
+		if !ok || tcp.DstPort != srcPort {
+			continue
+		}
+
+		switch {
+		case tcp.SYN && tcp.ACK:
+			t.record(int(tcp.SrcPort), StateOpen)
+		case tcp.RST:
+			t.record(int(tcp.SrcPort), StateClosed)
+		}
+	}
+}
+
+// record stores state for port, keeping the first answer seen.
+func (t *synReplyTracker) record(port int, state string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, seen := t.states[port]; !seen {
+		t.states[port] = state
+	}
+}
+
+// state reports the recorded state for port, if any reply has arrived.
+func (t *synReplyTracker) state(port int) (string, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.states[port]
+	return state, ok
+}
+
+// arpResolve discovers the hardware address of dst by issuing an ARP
+// request over handle and waiting for the corresponding reply.
+func arpResolve(handle *pcap.Handle, iface *net.Interface, srcIP, dst net.IP) (net.HardwareAddr, error) {
+	eth := layers.Ethernet{
+		SrcMAC:       iface.HardwareAddr,
+		DstMAC:       net.HardwareAddr{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+		EthernetType: layers.EthernetTypeARP,
+	}
+	arp := layers.ARP{
+		AddrType:          layers.LinkTypeEthernet,
+		Protocol:          layers.EthernetTypeIPv4,
+		HwAddressSize:     6,
+		ProtAddressSize:   4,
+		Operation:         layers.ARPRequest,
+		SourceHwAddress:   iface.HardwareAddr,
+		SourceProtAddress: srcIP.To4(),
+		DstHwAddress:      net.HardwareAddr{0, 0, 0, 0, 0, 0},
+		DstProtAddress:    dst.To4(),
+	}
+
+	buf := gopacket.NewSerializeBuffer()
+	opts := gopacket.SerializeOptions{ComputeChecksums: true, FixLengths: true}
+	if err := gopacket.SerializeLayers(buf, opts, &eth, &arp); err != nil {
+		return nil, fmt.Errorf("serialize ARP request: %w", err)
+	}
+	if err := handle.WritePacketData(buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	if err := handle.SetBPFFilter("arp"); err != nil {
+		return nil, err
+	}
+
+	// The handle was opened with pcap.BlockForever, so a plain range over
+	// source.Packets() never notices a wall-clock deadline when nothing
+	// arrives to unblock it. select against a real timer instead, so a dead
+	// or non-existent dst still returns within 3 seconds.
+	source := gopacket.NewPacketSource(handle, handle.LinkType())
+	packets := source.Packets()
+	timeout := time.After(3 * time.Second)
+	for {
+		select {
+		case packet, ok := <-packets:
+			if !ok {
+				return nil, fmt.Errorf("no ARP reply from %s", dst)
+			}
+			arpLayer := packet.Layer(layers.LayerTypeARP)
+			if arpLayer == nil {
+				continue
+			}
+			reply := arpLayer.(*layers.ARP)
+			if net.IP(reply.SourceProtAddress).Equal(dst) {
+				return net.HardwareAddr(reply.SourceHwAddress), nil
+			}
+		case <-timeout:
+			return nil, fmt.Errorf("no ARP reply from %s", dst)
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package scanner
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/JohnMichaelJarvis/cybersecurity-toolkit/tools/custom_built/probes"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+)
+
+// Port states returned by UDPScan, in addition to StateOpen.
+const (
+	StateOpenFiltered = "open|filtered"
+)
+
+// UDPScan probes each of ports on target with a protocol-specific payload
+// from the probes package, retrying up to retries times, and classifies
+// each port as open (a reply arrived), open|filtered (no reply at all,
+// the common case for a silently dropping firewall), or closed (an ICMP
+// port-unreachable came back). Closed detection needs a raw ICMP listener;
+// if one can't be opened (e.g. unprivileged), every silent port is reported
+// open|filtered instead of closed. Ports are probed across a pool of
+// workers concurrent goroutines, the same shape as the connect-scan worker
+// pool, since each probe can block for up to (retries+1)*timeout.
+func UDPScan(target net.IP, ports []int, timeout time.Duration, retries int, workers int) (map[int]string, error) {
+	unreachable := newUnreachableTracker(target)
+	defer unreachable.stop()
+
+	type result struct {
+		port  int
+		state string
+	}
+
+	jobs := make(chan int, len(ports))
+	for _, port := range ports {
+		jobs <- port
+	}
+	close(jobs)
+
+	resultsCh := make(chan result, len(ports))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for port := range jobs {
+				resultsCh <- result{port: port, state: probePort(target, port, timeout, retries, unreachable)}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	results := make(map[int]string, len(ports))
+	for r := range resultsCh {
+		results[r.port] = r.state
+	}
+	return results, nil
+}
+
+// probePort sends the probe for port up to retries+1 times, returning as
+// soon as a reply or an ICMP unreachable is observed.
+func probePort(target net.IP, port int, timeout time.Duration, retries int, unreachable *unreachableTracker) string {
+	payload := probes.Probe(port)
+	address := &net.UDPAddr{IP: target, Port: port}
+
+	for attempt := 0; attempt <= retries; attempt++ {
+		conn, err := net.DialUDP("udp", nil, address)
+		if err != nil {
+			continue
+		}
+
+		conn.Write(payload)
+		conn.SetReadDeadline(time.Now().Add(timeout))
+		buf := make([]byte, 512)
+		n, err := conn.Read(buf)
+		conn.Close()
+		if err == nil && n > 0 {
+			return StateOpen
+		}
+
+		if unreachable.sawPort(port) {
+			return StateClosed
+		}
+	}
+
+	return StateOpenFiltered
+}
+
+// unreachableTracker records, per port, whether target has answered with an
+// ICMP port-unreachable message.
+type unreachableTracker struct {
+	mu    sync.Mutex
+	ports map[int]bool
+	conn  *icmp.PacketConn // nil if a raw listener couldn't be opened
+	done  chan struct{}
+}
+
+// newUnreachableTracker opens a raw ICMP listener, if permitted, and starts
+// recording destination-unreachable replies from target in the background.
+func newUnreachableTracker(target net.IP) *unreachableTracker {
+	t := &unreachableTracker{ports: make(map[int]bool), done: make(chan struct{})}
+
+	conn, err := icmp.ListenPacket("ip4:icmp", "0.0.0.0")
+	if err != nil {
+		// No raw socket permission: every silent port will be reported
+		// open|filtered rather than closed.
+		return t
+	}
+	t.conn = conn
+
+	go t.listen(target)
+	return t
+}
+
+// sawPort reports whether an ICMP unreachable naming port has been seen.
+func (t *unreachableTracker) sawPort(port int) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.ports[port]
+}
+
+// stop shuts down the background listener, if one was started.
+func (t *unreachableTracker) stop() {
+	close(t.done)
+	if t.conn != nil {
+		t.conn.Close()
+	}
+}
+
+// listen reads ICMP packets from target and records any port-unreachable
+// replies until stop is called.
+func (t *unreachableTracker) listen(target net.IP) {
+	buf := make([]byte, 1500)
+	for {
+		select {
+		case <-t.done:
+			return
+		default:
+		}
+
+		t.conn.SetReadDeadline(time.Now().Add(250 * time.Millisecond))
+		n, peer, err := t.conn.ReadFrom(buf)
+		if err != nil {
+			continue
+		}
+
+		peerIP, ok := peer.(*net.IPAddr)
+		if !ok || !peerIP.IP.Equal(target) {
+			continue
+		}
+
+		msg, err := icmp.ParseMessage(1, buf[:n])
+		if err != nil || msg.Type != ipv4.ICMPTypeDestinationUnreachable {
+			continue
+		}
+
+		if port, ok := originalDstPort(msg.Body); ok {
+			t.mu.Lock()
+			t.ports[port] = true
+			t.mu.Unlock()
+		}
+	}
+}
+
+// originalDstPort extracts the destination port from the UDP header
+// embedded in an ICMP destination-unreachable message's body, which quotes
+// the IP header and first 8 bytes of the datagram that triggered it.
+func originalDstPort(body icmp.MessageBody) (int, bool) {
+	du, ok := body.(*icmp.DstUnreach)
+	if !ok || len(du.Data) < 20 {
+		return 0, false
+	}
+
+	ihl := int(du.Data[0]&0x0f) * 4
+	if len(du.Data) < ihl+4 {
+		return 0, false
+	}
+
+	port := int(du.Data[ihl+2])<<8 | int(du.Data[ihl+3])
+	return port, true
+}
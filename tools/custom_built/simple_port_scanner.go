@@ -1,19 +1,316 @@
 package main
 
 import (
-    "fmt"
-    "net"
-    "time"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JohnMichaelJarvis/cybersecurity-toolkit/tools/custom_built/discover"
+	"github.com/JohnMichaelJarvis/cybersecurity-toolkit/tools/custom_built/fingerprint"
+	"github.com/JohnMichaelJarvis/cybersecurity-toolkit/tools/custom_built/reporter"
+	"github.com/JohnMichaelJarvis/cybersecurity-toolkit/tools/custom_built/scanner"
 )
 
+// scanResult captures a single open host:port finding.
+type scanResult struct {
+	host    string
+	port    int
+	service string
+	banner  string
+}
+
 func main() {
-    target := "scanme.nmap.org"
-    for port := 20; port <= 1024; port++ {
-        address := fmt.Sprintf("%s:%d", target, port)
-        conn, err := net.DialTimeout("tcp", address, 1*time.Second)
-        if err == nil {
-            fmt.Printf("Port %d open\n", port)
-            conn.Close()
-        }
-    }
+	target := flag.String("target", "scanme.nmap.org", "hostname, single IP, or CIDR range (e.g. 192.168.1.0/24)")
+	ports := flag.String("ports", "20-1024", "comma-separated ports and ranges (e.g. 1-1024,3389,8000-8100)")
+	workers := flag.Int("workers", 100, "number of concurrent worker goroutines")
+	timeout := flag.Duration("timeout", 1*time.Second, "dial timeout per port")
+	mode := flag.String("mode", "connect", "scan mode: connect, syn, or udp")
+	discoverFirst := flag.Bool("discover", false, "ping-sweep a CIDR -target and only scan hosts that respond")
+	output := flag.String("output", "text", "output format: text, json, or xml")
+	outFile := flag.String("o", "", "write output to this file instead of stdout")
+	retries := flag.Int("retries", 2, "probe retries per port for -mode udp")
+	flag.Parse()
+
+	var hosts []string
+	if *discoverFirst {
+		alive, err := discover.PingSweep(*target, *timeout, *workers)
+		if err != nil {
+			log.Fatalf("discover: %v", err)
+		}
+		for _, ip := range alive {
+			hosts = append(hosts, ip.String())
+		}
+		fmt.Printf("%d host(s) alive\n", len(hosts))
+	} else {
+		var err error
+		hosts, err = expandTarget(*target)
+		if err != nil {
+			log.Fatalf("target: %v", err)
+		}
+	}
+
+	portList, err := expandPorts(*ports)
+	if err != nil {
+		log.Fatalf("ports: %v", err)
+	}
+
+	protocol := "tcp"
+	if *mode == "udp" {
+		protocol = "udp"
+	}
+	rep, closeOut, err := newReporter(*output, *outFile, *target, *workers, protocol)
+	if err != nil {
+		log.Fatalf("output: %v", err)
+	}
+	defer closeOut()
+
+	switch *mode {
+	case "connect":
+		scan(hosts, portList, *workers, *timeout, rep)
+	case "syn":
+		if err := synScanHosts(hosts, portList, *timeout, rep); err != nil {
+			log.Fatalf("syn scan: %v", err)
+		}
+	case "udp":
+		if err := udpScanHosts(hosts, portList, *timeout, *retries, *workers, rep); err != nil {
+			log.Fatalf("udp scan: %v", err)
+		}
+	default:
+		log.Fatalf("unknown -mode %q (want connect, syn, or udp)", *mode)
+	}
+
+	if err := rep.Finish(); err != nil {
+		log.Fatalf("output: %v", err)
+	}
+}
+
+// newReporter builds the Reporter named by format, writing to outFile (or
+// stdout if empty). The returned close func must be called once the
+// reporter is done writing.
+func newReporter(format, outFile, target string, workers int, protocol string) (reporter.Reporter, func(), error) {
+	w := io.Writer(os.Stdout)
+	closeOut := func() {}
+	if outFile != "" {
+		f, err := os.Create(outFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create %s: %w", outFile, err)
+		}
+		w = f
+		closeOut = func() { f.Close() }
+	}
+
+	switch format {
+	case "text":
+		return reporter.NewTextReporter(w), closeOut, nil
+	case "json":
+		return reporter.NewJSONReporter(w, target, workers), closeOut, nil
+	case "xml":
+		return reporter.NewNmapXMLReporter(w, target, workers, protocol), closeOut, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown -output %q (want text, json, or xml)", format)
+	}
+}
+
+// synScanHosts runs a SYN scan against each resolved host in turn and
+// reports its open ports.
+func synScanHosts(hosts []string, ports []int, timeout time.Duration, rep reporter.Reporter) error {
+	for _, host := range hosts {
+		ip, err := resolveIP(host)
+		if err != nil {
+			return fmt.Errorf("resolve %s: %w", host, err)
+		}
+
+		states, err := scanner.SYNScan(ip, ports, timeout)
+		if err != nil {
+			return fmt.Errorf("%s: %w", host, err)
+		}
+
+		openPorts := make([]int, 0, len(states))
+		for port, state := range states {
+			if state == scanner.StateOpen {
+				openPorts = append(openPorts, port)
+			}
+		}
+		sort.Ints(openPorts)
+		for _, port := range openPorts {
+			rep.Report(host, port, reporter.PortOpen, "", "")
+		}
+	}
+	return nil
+}
+
+// udpScanHosts runs a UDP scan against each resolved host in turn and
+// reports every port found open or open|filtered; closed ports (an ICMP
+// port-unreachable came back) aren't reported, matching the other modes.
+func udpScanHosts(hosts []string, ports []int, timeout time.Duration, retries int, workers int, rep reporter.Reporter) error {
+	for _, host := range hosts {
+		ip, err := resolveIP(host)
+		if err != nil {
+			return fmt.Errorf("resolve %s: %w", host, err)
+		}
+
+		states, err := scanner.UDPScan(ip, ports, timeout, retries, workers)
+		if err != nil {
+			return fmt.Errorf("%s: %w", host, err)
+		}
+
+		reportable := make([]int, 0, len(states))
+		for port, state := range states {
+			if state != scanner.StateClosed {
+				reportable = append(reportable, port)
+			}
+		}
+		sort.Ints(reportable)
+		for _, port := range reportable {
+			state := reporter.PortOpen
+			if states[port] != scanner.StateOpen {
+				state = reporter.PortOpenFiltered
+			}
+			rep.Report(host, port, state, "", "")
+		}
+	}
+	return nil
+}
+
+// resolveIP resolves a hostname or dotted-quad string to a single IP.
+func resolveIP(host string) (net.IP, error) {
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+	addrs, err := net.LookupIP(host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no addresses found for %s", host)
+	}
+	return addrs[0], nil
+}
+
+// expandTarget turns a hostname, single IP, or CIDR range into the list of
+// host strings to scan.
+func expandTarget(target string) ([]string, error) {
+	if !strings.Contains(target, "/") {
+		return []string{target}, nil
+	}
+
+	ip, ipNet, err := net.ParseCIDR(target)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CIDR %q: %w", target, err)
+	}
+
+	var hosts []string
+	for cur := ip.Mask(ipNet.Mask); ipNet.Contains(cur); incIP(cur) {
+		hosts = append(hosts, cur.String())
+	}
+
+	// Drop network and broadcast addresses for anything smaller than a /31.
+	if len(hosts) > 2 {
+		hosts = hosts[1 : len(hosts)-1]
+	}
+	return hosts, nil
+}
+
+// incIP increments an IP address in place, treating it as a big-endian
+// byte slice (used to walk a CIDR range).
+func incIP(ip net.IP) {
+	for i := len(ip) - 1; i >= 0; i-- {
+		ip[i]++
+		if ip[i] != 0 {
+			return
+		}
+	}
+}
+
+// expandPorts parses a spec like "1-1024,3389,8000-8100" into a sorted,
+// de-duplicated list of ports.
+func expandPorts(spec string) ([]int, error) {
+	seen := make(map[int]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if lo, hi, ok := strings.Cut(part, "-"); ok {
+			start, err := strconv.Atoi(lo)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port range %q: %w", part, err)
+			}
+			end, err := strconv.Atoi(hi)
+			if err != nil {
+				return nil, fmt.Errorf("invalid port range %q: %w", part, err)
+			}
+			for p := start; p <= end; p++ {
+				seen[p] = true
+			}
+			continue
+		}
+
+		p, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %q: %w", part, err)
+		}
+		seen[p] = true
+	}
+
+	ports := make([]int, 0, len(seen))
+	for p := range seen {
+		ports = append(ports, p)
+	}
+	sort.Ints(ports)
+	return ports, nil
+}
+
+// scan fans every host/port pair out across a fixed worker pool, each
+// worker dialing with net.DialTimeout, and reports every open port found.
+func scan(hosts []string, ports []int, workers int, timeout time.Duration, rep reporter.Reporter) {
+	type job struct {
+		host string
+		port int
+	}
+
+	jobs := make(chan job, len(hosts)*len(ports))
+	for _, h := range hosts {
+		for _, p := range ports {
+			jobs <- job{host: h, port: p}
+		}
+	}
+	close(jobs)
+
+	resultsCh := make(chan scanResult, len(hosts)*len(ports))
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				address := net.JoinHostPort(j.host, strconv.Itoa(j.port))
+				conn, err := net.DialTimeout("tcp", address, timeout)
+				if err != nil {
+					continue
+				}
+				service, banner := fingerprint.Fingerprint(conn, j.port)
+				conn.Close()
+				resultsCh <- scanResult{host: j.host, port: j.port, service: service, banner: banner}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultsCh)
+	}()
+
+	for r := range resultsCh {
+		rep.Report(r.host, r.port, reporter.PortOpen, r.service, r.banner)
+	}
 }
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"net"
+	"reflect"
+	"testing"
+)
+
+func TestExpandPorts(t *testing.T) {
+	cases := []struct {
+		name    string
+		spec    string
+		want    []int
+		wantErr bool
+	}{
+		{name: "single port", spec: "22", want: []int{22}},
+		{name: "range", spec: "20-23", want: []int{20, 21, 22, 23}},
+		{name: "mixed and deduped", spec: "1-3,2,3389", want: []int{1, 2, 3, 3389}},
+		{name: "whitespace tolerated", spec: " 22 , 80 ", want: []int{22, 80}},
+		{name: "invalid port", spec: "abc", wantErr: true},
+		{name: "invalid range", spec: "10-abc", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := expandPorts(tc.spec)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expandPorts(%q) = %v, want error", tc.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandPorts(%q): %v", tc.spec, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expandPorts(%q) = %v, want %v", tc.spec, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandTarget(t *testing.T) {
+	cases := []struct {
+		name    string
+		target  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single ip passes through", target: "192.168.1.5", want: []string{"192.168.1.5"}},
+		{name: "hostname passes through", target: "scanme.nmap.org", want: []string{"scanme.nmap.org"}},
+		{
+			name:   "/30 drops network and broadcast",
+			target: "10.0.0.0/30",
+			want:   []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{name: "invalid CIDR", target: "10.0.0.0/99", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := expandTarget(tc.target)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expandTarget(%q) = %v, want error", tc.target, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expandTarget(%q): %v", tc.target, err)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("expandTarget(%q) = %v, want %v", tc.target, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIncIP(t *testing.T) {
+	cases := []struct {
+		start string
+		want  string
+	}{
+		{start: "10.0.0.1", want: "10.0.0.2"},
+		{start: "10.0.0.255", want: "10.0.1.0"},
+		{start: "255.255.255.255", want: "0.0.0.0"},
+	}
+
+	for _, tc := range cases {
+		ip := net.ParseIP(tc.start).To4()
+		incIP(ip)
+		if ip.String() != tc.want {
+			t.Errorf("incIP(%s) = %s, want %s", tc.start, ip, tc.want)
+		}
+	}
+}